@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"webcrawler/jobs"
+)
+
+// jobManager backs both the REST API and the websocket upload form, so a
+// job submitted through either surface runs through the same engine and
+// gets its own output directory under "jobs/".
+var jobManager *jobs.Manager
+
+// createJobRequest is the body accepted by POST /api/jobs. Seeds may be
+// given directly as a JSON list, or as the raw contents of an uploaded
+// CSV file in SeedsCSV.
+type createJobRequest struct {
+	Seeds             []string         `json:"seeds"`
+	SeedsCSV          string           `json:"seedsCSV"`
+	SeedsSitemap      string           `json:"seeds.sitemap"`
+	Scope             jobs.ScopeConfig `json:"scope"`
+	UserAgent         string           `json:"userAgent"`
+	RequestsPerSecond float64          `json:"requestsPerSecond"`
+	Concurrency       int              `json:"concurrency"`
+	MaxDepth          int              `json:"maxDepth"`
+	RulesConfig       string           `json:"rulesConfig"`
+}
+
+// handleCreateJob accepts a CSV or JSON list of seeds plus a crawl
+// config and starts a job in the background, returning its ID.
+func handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req createJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seeds := req.Seeds
+	if req.SeedsCSV != "" {
+		csvSeeds, err := seedsFromCSV(strings.NewReader(req.SeedsCSV))
+		if err != nil {
+			http.Error(w, "invalid seedsCSV: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		seeds = append(seeds, csvSeeds...)
+	}
+	if len(seeds) == 0 && req.SeedsSitemap == "" {
+		http.Error(w, "no seed URLs or sitemap given", http.StatusBadRequest)
+		return
+	}
+
+	cfg := jobs.Config{
+		Seeds:             jobs.SeedsConfig{URLs: seeds, Sitemap: req.SeedsSitemap},
+		Scope:             req.Scope,
+		UserAgent:         req.UserAgent,
+		RequestsPerSecond: req.RequestsPerSecond,
+		Concurrency:       req.Concurrency,
+		MaxDepth:          req.MaxDepth,
+		RulesConfig:       req.RulesConfig,
+	}
+
+	job, err := jobManager.Submit(cfg, nil)
+	if err != nil {
+		http.Error(w, "could not start job: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job.Snapshot())
+}
+
+// handleJobStatus returns a job's current status.
+func handleJobStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := jobManager.Get(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job.Snapshot())
+}
+
+// handleJobResults streams a job's output in the requested format. For
+// jsonl, results are streamed as they're produced: if the job is still
+// running, the response tails results.jsonl and flushes each new line
+// as soon as it's written, rather than waiting for the crawl to finish.
+func handleJobResults(w http.ResponseWriter, r *http.Request) {
+	job, ok := jobManager.Get(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		job.Wait()
+		w.Header().Set("Content-Type", "text/csv")
+		http.ServeFile(w, r, filepath.Join(job.Dir, "output.csv"))
+	case "warc":
+		job.Wait()
+		w.Header().Set("Content-Type", "application/warc")
+		http.ServeFile(w, r, filepath.Join(job.Dir, "output.warc"))
+	case "jsonl", "":
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		streamJSONL(w, job)
+	default:
+		http.Error(w, "unknown format", http.StatusBadRequest)
+	}
+}
+
+// streamJSONL writes job.Dir/results.jsonl to w as it grows, polling
+// for new data until the job finishes or a write to the client fails.
+func streamJSONL(w http.ResponseWriter, job *jobs.Job) {
+	flusher, _ := w.(http.Flusher)
+
+	path := filepath.Join(job.Dir, "results.jsonl")
+	var file *os.File
+	for file == nil {
+		f, err := os.Open(path)
+		if err == nil {
+			file = f
+			break
+		}
+		select {
+		case <-job.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	defer file.Close()
+
+	for {
+		if _, err := io.Copy(w, file); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-job.Done():
+			io.Copy(w, file)
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MissingAltTextCheck flags <img> elements with no alt attribute, or an
+// empty one.
+type MissingAltTextCheck struct{}
+
+func (c *MissingAltTextCheck) Name() string { return "missing-alt-text" }
+
+func (c *MissingAltTextCheck) Run(ctx *PageContext) []Finding {
+	var findings []Finding
+	ctx.Doc.Find("img").Each(func(i int, s *goquery.Selection) {
+		alt, exists := s.Attr("alt")
+		if exists && alt != "" {
+			return
+		}
+		src := s.AttrOr("src", "")
+		findings = append(findings, Finding{
+			Check:   c.Name(),
+			Message: "img missing alt text",
+			Detail:  fmt.Sprintf("<img src=%q>", src),
+		})
+	})
+	return findings
+}
@@ -0,0 +1,42 @@
+package checks
+
+import "testing"
+
+func TestMissingAltTextCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want int
+	}{
+		{
+			name: "img with alt text is clean",
+			html: `<img src="a.png" alt="a logo">`,
+			want: 0,
+		},
+		{
+			name: "img with no alt attribute is flagged",
+			html: `<img src="a.png">`,
+			want: 1,
+		},
+		{
+			name: "img with empty alt is flagged",
+			html: `<img src="a.png" alt="">`,
+			want: 1,
+		},
+		{
+			name: "no images is clean",
+			html: `<p>no images here</p>`,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{Doc: newDoc(t, tt.html)}
+			findings := (&MissingAltTextCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
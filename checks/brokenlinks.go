@@ -0,0 +1,47 @@
+package checks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// BrokenInternalLinksCheck flags links to other pages within the crawl
+// that resolved to a non-2xx status. It relies on ctx.ResolveStatus, so
+// it only reports on links the crawl actually visited.
+type BrokenInternalLinksCheck struct{}
+
+func (c *BrokenInternalLinksCheck) Name() string { return "broken-internal-links" }
+
+func (c *BrokenInternalLinksCheck) Run(ctx *PageContext) []Finding {
+	if ctx.ResolveStatus == nil {
+		return nil
+	}
+
+	base, err := url.Parse(ctx.URL)
+	if err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	ctx.Doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		target := base.ResolveReference(ref)
+
+		status, known := ctx.ResolveStatus(target.String())
+		if !known || (status >= 200 && status < 300) {
+			return
+		}
+		findings = append(findings, Finding{
+			Check:   c.Name(),
+			Message: "broken internal link",
+			Detail:  fmt.Sprintf("%s -> %d", target.String(), status),
+		})
+	})
+	return findings
+}
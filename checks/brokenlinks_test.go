@@ -0,0 +1,60 @@
+package checks
+
+import "testing"
+
+func TestBrokenInternalLinksCheck(t *testing.T) {
+	statuses := map[string]int{
+		"https://example.com/ok":      200,
+		"https://example.com/missing": 404,
+	}
+	resolve := func(url string) (int, bool) {
+		status, ok := statuses[url]
+		return status, ok
+	}
+
+	tests := []struct {
+		name          string
+		url           string
+		html          string
+		resolveStatus func(string) (int, bool)
+		want          int
+	}{
+		{
+			name:          "2xx link is clean",
+			url:           "https://example.com/",
+			html:          `<a href="/ok">ok</a>`,
+			resolveStatus: resolve,
+			want:          0,
+		},
+		{
+			name:          "4xx link is flagged",
+			url:           "https://example.com/",
+			html:          `<a href="/missing">missing</a>`,
+			resolveStatus: resolve,
+			want:          1,
+		},
+		{
+			name:          "link never visited by the crawl is ignored",
+			url:           "https://example.com/",
+			html:          `<a href="/unvisited">unvisited</a>`,
+			resolveStatus: resolve,
+			want:          0,
+		},
+		{
+			name: "nil ResolveStatus yields no findings",
+			url:  "https://example.com/",
+			html: `<a href="/missing">missing</a>`,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{URL: tt.url, Doc: newDoc(t, tt.html), ResolveStatus: tt.resolveStatus}
+			findings := (&BrokenInternalLinksCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
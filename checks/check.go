@@ -0,0 +1,35 @@
+// Package checks implements pluggable, per-page SEO/quality audits run
+// against a crawled document.
+package checks
+
+import (
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Finding is a single issue reported by a Check.
+type Finding struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// PageContext is the information available to a Check when it runs
+// against one crawled page.
+type PageContext struct {
+	URL           string
+	Doc           *goquery.Document
+	IgnoreClasses map[string]bool
+
+	// ResolveStatus looks up the last known HTTP status for an
+	// internal URL discovered elsewhere in the crawl. known is false
+	// if the crawl never visited that URL.
+	ResolveStatus func(url string) (status int, known bool)
+}
+
+// Check is a single pluggable page-level audit.
+type Check interface {
+	// Name identifies the check, e.g. for rule-config enable lists and
+	// for tagging the Findings it produces.
+	Name() string
+	Run(ctx *PageContext) []Finding
+}
@@ -0,0 +1,18 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// newDoc parses html for use as a PageContext.Doc in tests.
+func newDoc(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("parsing html: %v", err)
+	}
+	return doc
+}
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleConfig is the per-run rule configuration, uploaded alongside the
+// CSV of seed URLs as a YAML or JSON file.
+type RuleConfig struct {
+	// Checks lists which check names to run. Empty means "all registered checks".
+	Checks []string `json:"checks" yaml:"checks"`
+	// IgnoreClasses are CSS class values the self-referencing-links check should skip.
+	IgnoreClasses []string `json:"ignoreClasses" yaml:"ignoreClasses"`
+	// Thresholds holds free-form numeric knobs for checks that need them.
+	Thresholds map[string]float64 `json:"thresholds" yaml:"thresholds"`
+}
+
+// IgnoreClassSet returns IgnoreClasses as a lookup set for PageContext.
+func (rc RuleConfig) IgnoreClassSet() map[string]bool {
+	set := make(map[string]bool, len(rc.IgnoreClasses))
+	for _, class := range rc.IgnoreClasses {
+		set[class] = true
+	}
+	return set
+}
+
+// ParseConfig parses data as JSON first and falls back to YAML, since
+// operators may upload either.
+func ParseConfig(data []byte) (RuleConfig, error) {
+	var rc RuleConfig
+	if err := json.Unmarshal(data, &rc); err == nil {
+		return rc, nil
+	}
+	if err := yaml.Unmarshal(data, &rc); err != nil {
+		return RuleConfig{}, err
+	}
+	return rc, nil
+}
@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigJSON(t *testing.T) {
+	data := []byte(`{"checks": ["missing-alt-text"], "ignoreClasses": ["js-toggle"], "thresholds": {"maxLinks": 50}}`)
+
+	rc, err := ParseConfig(data)
+	if err != nil {
+		t.Fatalf("ParseConfig: %v", err)
+	}
+	if !reflect.DeepEqual(rc.Checks, []string{"missing-alt-text"}) {
+		t.Errorf("Checks = %v, want [missing-alt-text]", rc.Checks)
+	}
+	if !reflect.DeepEqual(rc.IgnoreClasses, []string{"js-toggle"}) {
+		t.Errorf("IgnoreClasses = %v, want [js-toggle]", rc.IgnoreClasses)
+	}
+	if rc.Thresholds["maxLinks"] != 50 {
+		t.Errorf("Thresholds[maxLinks] = %v, want 50", rc.Thresholds["maxLinks"])
+	}
+}
+
+func TestParseConfigYAML(t *testing.T) {
+	data := []byte("checks:\n  - heading-order\nignoreClasses:\n  - js-toggle\nthresholds:\n  maxLinks: 50\n")
+
+	rc, err := ParseConfig(data)
+	if err != nil {
+		t.Fatalf("ParseConfig: %v", err)
+	}
+	if !reflect.DeepEqual(rc.Checks, []string{"heading-order"}) {
+		t.Errorf("Checks = %v, want [heading-order]", rc.Checks)
+	}
+	if rc.Thresholds["maxLinks"] != 50 {
+		t.Errorf("Thresholds[maxLinks] = %v, want 50", rc.Thresholds["maxLinks"])
+	}
+}
+
+func TestParseConfigEmpty(t *testing.T) {
+	rc, err := ParseConfig(nil)
+	if err != nil {
+		t.Fatalf("ParseConfig(nil): %v", err)
+	}
+	if len(rc.Checks) != 0 {
+		t.Errorf("Checks = %v, want empty", rc.Checks)
+	}
+}
+
+func TestIgnoreClassSet(t *testing.T) {
+	rc := RuleConfig{IgnoreClasses: []string{"a", "b"}}
+	set := rc.IgnoreClassSet()
+	if !set["a"] || !set["b"] || set["c"] {
+		t.Errorf("IgnoreClassSet() = %v, want {a, b}", set)
+	}
+}
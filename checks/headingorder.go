@@ -0,0 +1,38 @@
+package checks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// HeadingOrderCheck flags a heading that skips one or more levels over
+// the previous heading, e.g. an <h3> directly following an <h1>.
+type HeadingOrderCheck struct{}
+
+func (c *HeadingOrderCheck) Name() string { return "heading-order" }
+
+func (c *HeadingOrderCheck) Run(ctx *PageContext) []Finding {
+	var findings []Finding
+	last := 0
+
+	ctx.Doc.Find("h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
+		if len(s.Nodes) == 0 {
+			return
+		}
+		level, err := strconv.Atoi(s.Nodes[0].Data[1:])
+		if err != nil {
+			return
+		}
+		if last != 0 && level > last+1 {
+			findings = append(findings, Finding{
+				Check:   c.Name(),
+				Message: "heading level skipped",
+				Detail:  fmt.Sprintf("h%d followed by h%d", last, level),
+			})
+		}
+		last = level
+	})
+	return findings
+}
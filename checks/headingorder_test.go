@@ -0,0 +1,42 @@
+package checks
+
+import "testing"
+
+func TestHeadingOrderCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want int
+	}{
+		{
+			name: "sequential headings are clean",
+			html: `<h1>A</h1><h2>B</h2><h3>C</h3>`,
+			want: 0,
+		},
+		{
+			name: "skipping a level is flagged",
+			html: `<h1>A</h1><h3>B</h3>`,
+			want: 1,
+		},
+		{
+			name: "stepping back down a level is clean",
+			html: `<h1>A</h1><h2>B</h2><h3>C</h3><h2>D</h2>`,
+			want: 0,
+		},
+		{
+			name: "empty document has no findings",
+			html: ``,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{Doc: newDoc(t, tt.html)}
+			findings := (&HeadingOrderCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
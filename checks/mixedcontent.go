@@ -0,0 +1,39 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MixedContentCheck flags http:// assets (images, scripts, stylesheets)
+// loaded from an https:// page.
+type MixedContentCheck struct{}
+
+func (c *MixedContentCheck) Name() string { return "mixed-content" }
+
+func (c *MixedContentCheck) Run(ctx *PageContext) []Finding {
+	if !strings.HasPrefix(ctx.URL, "https://") {
+		return nil
+	}
+
+	var findings []Finding
+	check := func(attr string) func(int, *goquery.Selection) {
+		return func(i int, s *goquery.Selection) {
+			v, _ := s.Attr(attr)
+			if !strings.HasPrefix(v, "http://") {
+				return
+			}
+			findings = append(findings, Finding{
+				Check:   c.Name(),
+				Message: "mixed content: http:// asset on https:// page",
+				Detail:  fmt.Sprintf("%s=%q", attr, v),
+			})
+		}
+	}
+
+	ctx.Doc.Find("img[src], script[src]").Each(check("src"))
+	ctx.Doc.Find(`link[rel="stylesheet"][href]`).Each(check("href"))
+	return findings
+}
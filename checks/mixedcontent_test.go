@@ -0,0 +1,47 @@
+package checks
+
+import "testing"
+
+func TestMixedContentCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		html string
+		want int
+	}{
+		{
+			name: "http image on https page is flagged",
+			url:  "https://example.com/",
+			html: `<img src="http://cdn.example.com/a.png">`,
+			want: 1,
+		},
+		{
+			name: "https image on https page is clean",
+			url:  "https://example.com/",
+			html: `<img src="https://cdn.example.com/a.png">`,
+			want: 0,
+		},
+		{
+			name: "http page is never checked",
+			url:  "http://example.com/",
+			html: `<img src="http://cdn.example.com/a.png">`,
+			want: 0,
+		},
+		{
+			name: "http stylesheet on https page is flagged",
+			url:  "https://example.com/",
+			html: `<link rel="stylesheet" href="http://cdn.example.com/a.css">`,
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{URL: tt.url, Doc: newDoc(t, tt.html)}
+			findings := (&MixedContentCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package checks
+
+import "fmt"
+
+// Registry holds the set of known Checks and runs a selected subset of
+// them against a page.
+type Registry struct {
+	checks map[string]Check
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]Check)}
+}
+
+// Register adds c to the registry, keyed by its Name.
+func (r *Registry) Register(c Check) {
+	r.checks[c.Name()] = c
+}
+
+// NewDefaultRegistry returns a Registry with every built-in check
+// registered.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(&SelfReferencingLinksCheck{})
+	r.Register(&TitleAndMetaCheck{})
+	r.Register(&MissingAltTextCheck{})
+	r.Register(&BrokenInternalLinksCheck{})
+	r.Register(&HeadingOrderCheck{})
+	r.Register(&MixedContentCheck{})
+	return r
+}
+
+// Run executes the named checks (or every registered check, if names is
+// empty) against ctx and returns their combined findings.
+func (r *Registry) Run(ctx *PageContext, names []string) ([]Finding, error) {
+	selected := r.checks
+	if len(names) > 0 {
+		selected = make(map[string]Check, len(names))
+		for _, name := range names {
+			c, ok := r.checks[name]
+			if !ok {
+				return nil, fmt.Errorf("checks: unknown check %q", name)
+			}
+			selected[name] = c
+		}
+	}
+
+	var findings []Finding
+	for _, c := range selected {
+		findings = append(findings, c.Run(ctx)...)
+	}
+	return findings, nil
+}
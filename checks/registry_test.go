@@ -0,0 +1,48 @@
+package checks
+
+import "testing"
+
+func TestRegistryRunSelected(t *testing.T) {
+	r := NewDefaultRegistry()
+	ctx := &PageContext{Doc: newDoc(t, `<html><head></head></html>`)}
+
+	findings, err := r.Run(ctx, []string{"title-and-meta"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, f := range findings {
+		if f.Check != "title-and-meta" {
+			t.Errorf("got finding from unselected check %q", f.Check)
+		}
+	}
+	if len(findings) == 0 {
+		t.Errorf("expected title-and-meta findings for a doc with no <title>")
+	}
+}
+
+func TestRegistryRunUnknownCheck(t *testing.T) {
+	r := NewDefaultRegistry()
+	ctx := &PageContext{Doc: newDoc(t, `<html></html>`)}
+
+	if _, err := r.Run(ctx, []string{"not-a-real-check"}); err == nil {
+		t.Fatal("expected an error for an unknown check name")
+	}
+}
+
+func TestRegistryRunAllChecksWhenNamesEmpty(t *testing.T) {
+	r := NewDefaultRegistry()
+	ctx := &PageContext{Doc: newDoc(t, `<html><head></head></html>`)}
+
+	findings, err := r.Run(ctx, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		seen[f.Check] = true
+	}
+	if !seen["title-and-meta"] {
+		t.Errorf("expected title-and-meta to run when names is empty, findings: %+v", findings)
+	}
+}
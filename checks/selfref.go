@@ -0,0 +1,31 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SelfReferencingLinksCheck flags anchors whose href is exactly "#" and
+// whose class isn't in ctx.IgnoreClasses.
+type SelfReferencingLinksCheck struct{}
+
+func (c *SelfReferencingLinksCheck) Name() string { return "self-referencing-links" }
+
+func (c *SelfReferencingLinksCheck) Run(ctx *PageContext) []Finding {
+	var findings []Finding
+	ctx.Doc.Find("a").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		class, _ := s.Attr("class")
+		if href != "#" || ctx.IgnoreClasses[class] {
+			return
+		}
+		title := s.AttrOr("title", "No title")
+		findings = append(findings, Finding{
+			Check:   c.Name(),
+			Message: "self-referencing link (href=\"#\")",
+			Detail:  fmt.Sprintf("<a href=\"%s\" class=\"%s\" title=\"%s\">", href, class, title),
+		})
+	})
+	return findings
+}
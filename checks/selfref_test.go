@@ -0,0 +1,39 @@
+package checks
+
+import "testing"
+
+func TestSelfReferencingLinksCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		html          string
+		ignoreClasses map[string]bool
+		want          int
+	}{
+		{
+			name: "href=# is flagged",
+			html: `<a href="#">top</a>`,
+			want: 1,
+		},
+		{
+			name:          "href=# with ignored class is not flagged",
+			html:          `<a href="#" class="js-toggle">menu</a>`,
+			ignoreClasses: map[string]bool{"js-toggle": true},
+			want:          0,
+		},
+		{
+			name: "regular link is not flagged",
+			html: `<a href="/about">about</a>`,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{Doc: newDoc(t, tt.html), IgnoreClasses: tt.ignoreClasses}
+			findings := (&SelfReferencingLinksCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
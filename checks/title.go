@@ -0,0 +1,34 @@
+package checks
+
+// TitleAndMetaCheck flags pages missing a <title>, with more than one
+// <title>, or missing a meta description.
+type TitleAndMetaCheck struct{}
+
+func (c *TitleAndMetaCheck) Name() string { return "title-and-meta" }
+
+func (c *TitleAndMetaCheck) Run(ctx *PageContext) []Finding {
+	var findings []Finding
+
+	titles := ctx.Doc.Find("title")
+	switch titles.Length() {
+	case 0:
+		findings = append(findings, Finding{Check: c.Name(), Message: "missing <title>"})
+	case 1:
+		// ok
+	default:
+		findings = append(findings, Finding{
+			Check:   c.Name(),
+			Message: "duplicate <title> elements",
+			Detail:  titles.First().Text(),
+		})
+	}
+
+	desc := ctx.Doc.Find(`meta[name="description"]`)
+	if desc.Length() == 0 {
+		findings = append(findings, Finding{Check: c.Name(), Message: "missing meta description"})
+	} else if content, _ := desc.First().Attr("content"); content == "" {
+		findings = append(findings, Finding{Check: c.Name(), Message: "empty meta description"})
+	}
+
+	return findings
+}
@@ -0,0 +1,42 @@
+package checks
+
+import "testing"
+
+func TestTitleAndMetaCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want int
+	}{
+		{
+			name: "title and description present is clean",
+			html: `<html><head><title>Home</title><meta name="description" content="A home page"></head></html>`,
+			want: 0,
+		},
+		{
+			name: "missing title and description flags both",
+			html: `<html><head></head></html>`,
+			want: 2,
+		},
+		{
+			name: "duplicate titles flags one finding",
+			html: `<html><head><title>A</title><title>B</title><meta name="description" content="x"></head></html>`,
+			want: 1,
+		},
+		{
+			name: "empty description is flagged",
+			html: `<html><head><title>Home</title><meta name="description" content=""></head></html>`,
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &PageContext{Doc: newDoc(t, tt.html)}
+			findings := (&TitleAndMetaCheck{}).Run(ctx)
+			if len(findings) != tt.want {
+				t.Fatalf("got %d findings, want %d: %+v", len(findings), tt.want, findings)
+			}
+		})
+	}
+}
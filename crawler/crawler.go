@@ -0,0 +1,263 @@
+// Package crawler implements a recursive, scope-limited crawl over a set
+// of seed URLs, on top of a persistent visited-set so a run can be
+// interrupted and resumed.
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"webcrawler/fetcher"
+)
+
+// Seed is a starting point for the crawl together with the scope that
+// governs which links discovered from it may be followed.
+type Seed struct {
+	URL   string
+	Scope Scope
+}
+
+// Page is the result of fetching a single URL during the crawl.
+type Page struct {
+	URL         string
+	Depth       int
+	Title       string
+	StatusCode  int
+	LoadTime    time.Duration
+	NotModified bool
+	Skipped     bool
+	Err         error
+}
+
+// Crawler walks Seeds up to MaxDepth, using Concurrency workers and
+// deduping against Store. If Warc is set, every fetched exchange is
+// also appended to the WARC file.
+type Crawler struct {
+	Seeds       []Seed
+	MaxDepth    int
+	Concurrency int
+	Store       *Store
+	Warc        *WarcWriter
+	Fetcher     *fetcher.Fetcher
+
+	// OnPage, if set, is called with each successfully parsed page so
+	// callers can run their own page-level checks without the crawler
+	// needing to know about them.
+	OnPage func(pageURL string, doc *goquery.Document)
+
+	// OnResult, if set, is called with every fetched Page (including
+	// errors, 304s, and skipped bodies) as soon as it's produced, so
+	// callers can stream results instead of waiting for Run to return.
+	OnResult func(Page)
+}
+
+type workItem struct {
+	url   *url.URL
+	depth int
+	seed  Seed
+}
+
+// workQueue is an unbounded FIFO of pending workItems. Unlike a fixed-size
+// channel, pushing to it never blocks, so a producer (the seed loop, or a
+// worker enqueueing a page's children) can never deadlock waiting for a
+// consumer that hasn't started yet or is itself blocked pushing.
+type workQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	items  []workItem
+	closed bool
+}
+
+func newWorkQueue() *workQueue {
+	q := &workQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *workQueue) push(item workItem) {
+	q.mu.Lock()
+	q.items = append(q.items, item)
+	q.mu.Unlock()
+	q.cond.Signal()
+}
+
+// close unblocks any workers waiting in pop once no more items will arrive.
+func (q *workQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// pop blocks until an item is available or the queue is closed and drained.
+func (q *workQueue) pop() (workItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.items) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return workItem{}, false
+	}
+	item := q.items[0]
+	q.items = q.items[1:]
+	return item, true
+}
+
+// Run crawls all seeds to completion and returns one Page per fetched URL.
+func (c *Crawler) Run() ([]Page, error) {
+	if c.Concurrency <= 0 {
+		c.Concurrency = 1
+	}
+
+	queue := newWorkQueue()
+	var pending sync.WaitGroup
+
+	var seenMu sync.Mutex
+	seen := make(map[string]bool)
+
+	enqueue := func(item workItem) {
+		key := item.url.String()
+
+		seenMu.Lock()
+		alreadySeen := seen[key]
+		seen[key] = true
+		seenMu.Unlock()
+		if alreadySeen {
+			return
+		}
+
+		visited, err := c.Store.Visited(key)
+		if err != nil {
+			fmt.Printf("crawler: error checking visited-set for %s: %v\n", key, err)
+		}
+		if visited {
+			return
+		}
+
+		pending.Add(1)
+		queue.push(item)
+	}
+
+	for _, seed := range c.Seeds {
+		u, err := url.Parse(seed.URL)
+		if err != nil {
+			fmt.Printf("crawler: skipping invalid seed %q: %v\n", seed.URL, err)
+			continue
+		}
+		enqueue(workItem{url: u, depth: 0, seed: seed})
+	}
+
+	go func() {
+		pending.Wait()
+		queue.close()
+	}()
+
+	var mu sync.Mutex
+	var pages []Page
+
+	process := func(item workItem) {
+		defer pending.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("crawler: recovered from panic fetching %s: %v\n", item.url, r)
+			}
+		}()
+
+		page, children := c.fetch(item)
+
+		if err := c.Store.MarkVisited(item.url.String()); err != nil {
+			fmt.Printf("crawler: error marking %s visited: %v\n", item.url, err)
+		}
+
+		mu.Lock()
+		pages = append(pages, page)
+		mu.Unlock()
+
+		for _, child := range children {
+			enqueue(child)
+		}
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for {
+				item, ok := queue.pop()
+				if !ok {
+					return
+				}
+				process(item)
+			}
+		}()
+	}
+	workers.Wait()
+
+	return pages, nil
+}
+
+// fetch downloads item.url through the Fetcher, records it (and
+// optionally a WARC exchange), and returns the in-scope children
+// discovered on the page.
+func (c *Crawler) fetch(item workItem) (page Page, children []workItem) {
+	if c.OnResult != nil {
+		defer func() { c.OnResult(page) }()
+	}
+
+	start := time.Now()
+	result, err := c.Fetcher.Fetch(item.url.String())
+	page = Page{URL: item.url.String(), Depth: item.depth, LoadTime: time.Since(start)}
+	if err != nil {
+		page.Err = err
+		return page, nil
+	}
+	page.StatusCode = result.StatusCode
+	page.NotModified = result.NotModified
+	page.Skipped = result.Skipped
+
+	if c.Warc != nil {
+		if err := c.Warc.WriteExchange(item.url.String(), c.Fetcher.UserAgent, result.StatusCode, result.Header, result.Body); err != nil {
+			fmt.Printf("crawler: error writing WARC record for %s: %v\n", item.url, err)
+		}
+	}
+
+	if result.NotModified || result.Skipped {
+		return page, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(result.Body))
+	if err != nil {
+		page.Err = err
+		return page, nil
+	}
+	page.Title = doc.Find("title").First().Text()
+
+	if c.OnPage != nil {
+		c.OnPage(item.url.String(), doc)
+	}
+
+	if item.depth >= c.MaxDepth {
+		return page, nil
+	}
+
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		child := item.url.ResolveReference(ref)
+		if !item.seed.Scope.Allowed(child, item.url) {
+			return
+		}
+		children = append(children, workItem{url: child, depth: item.depth + 1, seed: item.seed})
+	})
+	return page, children
+}
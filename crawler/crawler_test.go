@@ -0,0 +1,169 @@
+package crawler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"webcrawler/fetcher"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := OpenStore(filepath.Join(t.TempDir(), "visited.db"))
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func newTestFetcher(store *Store) *fetcher.Fetcher {
+	f := fetcher.New("test-agent", store)
+	f.Limiter = fetcher.NewHostLimiter(1000, 1000, 4)
+	return f
+}
+
+func pageURLs(pages []Page) []string {
+	urls := make([]string, len(pages))
+	for i, p := range pages {
+		urls[i] = p.URL
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+func TestCrawlerRunFollowsInScopeLinks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="/a">a</a><a href="/b">b</a><a href="https://other.example/x">off-site</a></body></html>`))
+	})
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="/c">c</a></body></html>`))
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>no links here</body></html>`))
+	})
+	mux.HandleFunc("/c", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>leaf page</body></html>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	scope, err := NewScope([]string{"http", "https"}, true, "", nil)
+	if err != nil {
+		t.Fatalf("NewScope: %v", err)
+	}
+
+	c := &Crawler{
+		Seeds:       []Seed{{URL: srv.URL + "/", Scope: scope}},
+		MaxDepth:    2,
+		Concurrency: 2,
+		Store:       store,
+		Fetcher:     newTestFetcher(store),
+	}
+
+	pages, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	got := pageURLs(pages)
+	want := []string{srv.URL + "/", srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("fetched %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fetched %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCrawlerRunDedupesConcurrentEnqueues(t *testing.T) {
+	var hits int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="/shared">s1</a><a href="/shared">s2</a></body></html>`))
+	})
+	mux.HandleFunc("/shared", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			hits++
+		}
+		w.Write([]byte(`<html><body>shared page</body></html>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	scope, err := NewScope([]string{"http", "https"}, true, "", nil)
+	if err != nil {
+		t.Fatalf("NewScope: %v", err)
+	}
+
+	c := &Crawler{
+		Seeds:       []Seed{{URL: srv.URL + "/", Scope: scope}},
+		MaxDepth:    1,
+		Concurrency: 4,
+		Store:       store,
+		Fetcher:     newTestFetcher(store),
+	}
+
+	pages, err := c.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("got %d pages, want 2 (seed + deduped /shared): %v", len(pages), pageURLs(pages))
+	}
+	if hits != 1 {
+		t.Errorf("/shared was fetched %d times, want exactly 1", hits)
+	}
+}
+
+func TestCrawlerRunSkipsAlreadyVisitedOnResume(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="/a">a</a></body></html>`))
+	})
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>leaf</body></html>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	store := newTestStore(t)
+	scope, err := NewScope([]string{"http", "https"}, true, "", nil)
+	if err != nil {
+		t.Fatalf("NewScope: %v", err)
+	}
+
+	run := func() []Page {
+		c := &Crawler{
+			Seeds:       []Seed{{URL: srv.URL + "/", Scope: scope}},
+			MaxDepth:    1,
+			Concurrency: 2,
+			Store:       store,
+			Fetcher:     newTestFetcher(store),
+		}
+		pages, err := c.Run()
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return pages
+	}
+
+	first := run()
+	if len(first) != 2 {
+		t.Fatalf("first run fetched %v, want 2 pages", pageURLs(first))
+	}
+
+	second := run()
+	if len(second) != 0 {
+		t.Fatalf("second run against the same store refetched %v, want nothing (already visited)", pageURLs(second))
+	}
+}
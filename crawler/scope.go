@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Scope decides whether a resolved link is allowed to be enqueued for
+// a given seed. It is evaluated once per discovered child URL.
+type Scope struct {
+	// AllowedSchemes restricts which URL schemes are followed, e.g. "http", "https".
+	AllowedSchemes []string
+	// SameHost, when true, only allows URLs sharing the seed's host.
+	SameHost bool
+	// PathPrefix, when set, only allows URLs whose path starts with it.
+	PathPrefix string
+	// Exclude is a list of regexes matched against the full URL; any match is rejected.
+	Exclude []*regexp.Regexp
+}
+
+// NewScope builds a Scope from raw config, compiling the exclude patterns.
+func NewScope(allowedSchemes []string, sameHost bool, pathPrefix string, excludePatterns []string) (Scope, error) {
+	s := Scope{
+		AllowedSchemes: allowedSchemes,
+		SameHost:       sameHost,
+		PathPrefix:     pathPrefix,
+	}
+	for _, p := range excludePatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return Scope{}, err
+		}
+		s.Exclude = append(s.Exclude, re)
+	}
+	return s, nil
+}
+
+// Allowed reports whether u may be crawled, given the seed it was discovered from.
+func (s Scope) Allowed(u *url.URL, seed *url.URL) bool {
+	if len(s.AllowedSchemes) > 0 && !containsFold(s.AllowedSchemes, u.Scheme) {
+		return false
+	}
+	if s.SameHost && !strings.EqualFold(u.Hostname(), seed.Hostname()) {
+		return false
+	}
+	if s.PathPrefix != "" && !strings.HasPrefix(u.Path, s.PathPrefix) {
+		return false
+	}
+	for _, re := range s.Exclude {
+		if re.MatchString(u.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
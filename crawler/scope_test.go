@@ -0,0 +1,99 @@
+package crawler
+
+import (
+	"net/url"
+	"testing"
+)
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", raw, err)
+	}
+	return u
+}
+
+func TestScopeAllowed(t *testing.T) {
+	seed := mustParseURL(t, "https://example.com/docs/")
+
+	tests := []struct {
+		name  string
+		scope Scope
+		url   string
+		want  bool
+	}{
+		{
+			name:  "disallowed scheme is rejected",
+			scope: Scope{AllowedSchemes: []string{"https"}},
+			url:   "http://example.com/page",
+			want:  false,
+		},
+		{
+			name:  "allowed scheme is matched case-insensitively",
+			scope: Scope{AllowedSchemes: []string{"HTTPS"}},
+			url:   "https://example.com/page",
+			want:  true,
+		},
+		{
+			name:  "same-host rejects a different host",
+			scope: Scope{SameHost: true},
+			url:   "https://other.com/page",
+			want:  false,
+		},
+		{
+			name:  "same-host allows the seed's host",
+			scope: Scope{SameHost: true},
+			url:   "https://example.com/page",
+			want:  true,
+		},
+		{
+			name:  "path prefix rejects outside paths",
+			scope: Scope{PathPrefix: "/docs"},
+			url:   "https://example.com/blog/post",
+			want:  false,
+		},
+		{
+			name:  "path prefix allows matching paths",
+			scope: Scope{PathPrefix: "/docs"},
+			url:   "https://example.com/docs/page",
+			want:  true,
+		},
+		{
+			name:  "exclude regex rejects a match",
+			scope: mustScope(t, nil, false, "", []string{`\.pdf$`}),
+			url:   "https://example.com/file.pdf",
+			want:  false,
+		},
+		{
+			name:  "exclude regex allows a non-match",
+			scope: mustScope(t, nil, false, "", []string{`\.pdf$`}),
+			url:   "https://example.com/file.html",
+			want:  true,
+		},
+		{
+			name:  "no constraints allows everything",
+			scope: Scope{},
+			url:   "https://anywhere.example/page",
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := mustParseURL(t, tt.url)
+			if got := tt.scope.Allowed(u, seed); got != tt.want {
+				t.Errorf("Allowed(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func mustScope(t *testing.T, allowedSchemes []string, sameHost bool, pathPrefix string, exclude []string) Scope {
+	t.Helper()
+	s, err := NewScope(allowedSchemes, sameHost, pathPrefix, exclude)
+	if err != nil {
+		t.Fatalf("NewScope: %v", err)
+	}
+	return s
+}
@@ -0,0 +1,119 @@
+package crawler
+
+import (
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	visitedBucket   = []byte("visited")
+	httpCacheBucket = []byte("http_cache")
+	sitemapBucket   = []byte("sitemap_lastmod")
+)
+
+// Store is a persistent visited-set backed by BoltDB, so a crawl can be
+// interrupted and resumed without re-fetching URLs it already processed.
+type Store struct {
+	db *bolt.DB
+}
+
+// OpenStore opens (creating if necessary) the BoltDB file at path.
+func OpenStore(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(visitedBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(httpCacheBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(sitemapBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Visited reports whether url has already been recorded as visited.
+func (s *Store) Visited(url string) (bool, error) {
+	var seen bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		seen = tx.Bucket(visitedBucket).Get([]byte(url)) != nil
+		return nil
+	})
+	return seen, err
+}
+
+// MarkVisited records url as visited. It is safe to call more than once
+// for the same URL.
+func (s *Store) MarkVisited(url string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(visitedBucket).Put([]byte(url), []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+}
+
+// Get implements fetcher.CacheStore, returning the ETag/Last-Modified
+// recorded for url on a prior run, if any.
+func (s *Store) Get(url string) (etag, lastModified string, ok bool, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(httpCacheBucket).Get([]byte(url))
+		if v == nil {
+			return nil
+		}
+		ok = true
+		parts := strings.SplitN(string(v), "\n", 2)
+		etag = parts[0]
+		if len(parts) > 1 {
+			lastModified = parts[1]
+		}
+		return nil
+	})
+	return etag, lastModified, ok, err
+}
+
+// Set implements fetcher.CacheStore, persisting the ETag/Last-Modified
+// pair seen for url so a later run can issue a conditional GET.
+func (s *Store) Set(url, etag, lastModified string) error {
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(httpCacheBucket).Put([]byte(url), []byte(etag+"\n"+lastModified))
+	})
+}
+
+// SitemapLastMod returns the <lastmod> recorded for a sitemap entry (a
+// URL or a nested sitemap) on a prior run, if any.
+func (s *Store) SitemapLastMod(url string) (lastMod string, ok bool, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(sitemapBucket).Get([]byte(url))
+		if v == nil {
+			return nil
+		}
+		ok = true
+		lastMod = string(v)
+		return nil
+	})
+	return lastMod, ok, err
+}
+
+// SetSitemapLastMod persists the <lastmod> seen for a sitemap entry so a
+// later run can skip it if it hasn't changed.
+func (s *Store) SetSitemapLastMod(url, lastMod string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sitemapBucket).Put([]byte(url), []byte(lastMod))
+	})
+}
@@ -0,0 +1,77 @@
+package crawler
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreVisited(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "visited.db"))
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	defer store.Close()
+
+	visited, err := store.Visited("https://example.com/a")
+	if err != nil {
+		t.Fatalf("Visited: %v", err)
+	}
+	if visited {
+		t.Fatalf("a fresh store should not report an unseen URL as visited")
+	}
+
+	if err := store.MarkVisited("https://example.com/a"); err != nil {
+		t.Fatalf("MarkVisited: %v", err)
+	}
+
+	visited, err = store.Visited("https://example.com/a")
+	if err != nil {
+		t.Fatalf("Visited: %v", err)
+	}
+	if !visited {
+		t.Fatalf("expected the URL to be visited after MarkVisited")
+	}
+}
+
+// TestStoreResumeAfterCrash exercises the resume contract: a URL that
+// was enqueued but never finished fetching before the process died must
+// still look unvisited once the store is reopened, so a resumed run
+// fetches it instead of silently skipping it.
+func TestStoreResumeAfterCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visited.db")
+
+	store1, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	// Simulate a crash while "https://example.com/mid-flight" was still
+	// queued or being fetched: nothing ever calls MarkVisited for it.
+	if err := store1.MarkVisited("https://example.com/done"); err != nil {
+		t.Fatalf("MarkVisited: %v", err)
+	}
+	if err := store1.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	store2, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("re-OpenStore: %v", err)
+	}
+	defer store2.Close()
+
+	done, err := store2.Visited("https://example.com/done")
+	if err != nil {
+		t.Fatalf("Visited: %v", err)
+	}
+	if !done {
+		t.Errorf("expected the completed URL to remain visited across reopen")
+	}
+
+	midFlight, err := store2.Visited("https://example.com/mid-flight")
+	if err != nil {
+		t.Fatalf("Visited: %v", err)
+	}
+	if midFlight {
+		t.Errorf("a URL that never finished fetching must not be marked visited, so a resumed run refetches it")
+	}
+}
@@ -0,0 +1,89 @@
+package crawler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WarcWriter appends request/response record pairs to a WARC 1.0 file so
+// operators can archive the pages a crawl fetched.
+type WarcWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWarcWriter wraps w, writing WARC records to it as they are produced.
+func NewWarcWriter(w io.Writer) *WarcWriter {
+	return &WarcWriter{w: w}
+}
+
+// WriteExchange appends a synthesized request record followed by the
+// response record for targetURI, linked via WARC-Concurrent-To.
+//
+// It is safe to call concurrently: crawl workers share one WarcWriter, and
+// writes are serialized so records from different goroutines never interleave.
+func (ww *WarcWriter) WriteExchange(targetURI, userAgent string, statusCode int, header http.Header, body []byte) error {
+	u, err := url.Parse(targetURI)
+	if err != nil {
+		return err
+	}
+
+	ww.mu.Lock()
+	defer ww.mu.Unlock()
+
+	reqID := newRecordID()
+	respID := newRecordID()
+	now := currentWarcDate()
+
+	reqBytes := []byte(fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nUser-Agent: %s\r\nAccept: */*\r\n\r\n",
+		u.RequestURI(), u.Host, userAgent))
+	if err := ww.writeRecord("request", targetURI, now, reqID, respID, reqBytes); err != nil {
+		return err
+	}
+
+	var headerBuf bytes.Buffer
+	fmt.Fprintf(&headerBuf, "HTTP/1.1 %d %s\r\n", statusCode, http.StatusText(statusCode))
+	header.Write(&headerBuf)
+	headerBuf.WriteString("\r\n")
+	respBytes := append(headerBuf.Bytes(), body...)
+
+	return ww.writeRecord("response", targetURI, now, respID, reqID, respBytes)
+}
+
+func (ww *WarcWriter) writeRecord(recordType, targetURI, date, id, concurrentTo string, payload []byte) error {
+	header := fmt.Sprintf(
+		"WARC/1.0\r\n"+
+			"WARC-Type: %s\r\n"+
+			"WARC-Record-ID: <urn:uuid:%s>\r\n"+
+			"WARC-Date: %s\r\n"+
+			"WARC-Target-URI: %s\r\n"+
+			"WARC-Concurrent-To: <urn:uuid:%s>\r\n"+
+			"Content-Type: application/http; msgtype=%s\r\n"+
+			"Content-Length: %d\r\n\r\n",
+		recordType, id, date, targetURI, concurrentTo, recordType, len(payload))
+
+	if _, err := io.WriteString(ww.w, header); err != nil {
+		return err
+	}
+	if _, err := ww.w.Write(payload); err != nil {
+		return err
+	}
+	_, err := io.WriteString(ww.w, "\r\n\r\n")
+	return err
+}
+
+func currentWarcDate() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func newRecordID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,39 @@
+package crawler
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWarcWriterConcurrentWritesDoNotInterleave(t *testing.T) {
+	var buf bytes.Buffer
+	ww := NewWarcWriter(&buf)
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			header := http.Header{"Content-Type": []string{"text/html"}}
+			if err := ww.WriteExchange("https://example.com/page", "test-agent", 200, header, []byte("body")); err != nil {
+				t.Errorf("WriteExchange: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	out := buf.String()
+	if got := strings.Count(out, "WARC-Type: request"); got != n {
+		t.Errorf("got %d request records, want %d", got, n)
+	}
+	if got := strings.Count(out, "WARC-Type: response"); got != n {
+		t.Errorf("got %d response records, want %d", got, n)
+	}
+	if got := strings.Count(out, "WARC/1.0"); got != 2*n {
+		t.Errorf("got %d WARC record headers, want %d (interleaving would corrupt this count)", got, 2*n)
+	}
+}
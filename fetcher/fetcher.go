@@ -0,0 +1,202 @@
+// Package fetcher wraps HTTP fetching with the politeness controls a
+// crawler needs: robots.txt compliance, per-host rate limiting, HEAD-first
+// probing of large or non-HTML responses, and conditional GETs.
+package fetcher
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowed is returned when robots.txt forbids fetching a URL.
+var ErrDisallowed = errors.New("fetcher: disallowed by robots.txt")
+
+// CacheStore persists the ETag/Last-Modified pair seen for a URL on a
+// prior run, so subsequent runs can issue conditional GETs.
+type CacheStore interface {
+	Get(url string) (etag, lastModified string, ok bool, err error)
+	Set(url, etag, lastModified string) error
+}
+
+// Result is the outcome of fetching a single URL.
+type Result struct {
+	URL         string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	NotModified bool // a conditional GET returned 304
+	Skipped     bool // HEAD indicated a large/non-HTML response, or the GET body exceeded MaxBodyBytes; no body returned
+}
+
+// HostStats tracks per-host counters for the lifetime of a Fetcher.
+type HostStats struct {
+	Requests    int
+	Fetched     int
+	NotModified int
+	Skipped     int
+	Disallowed  int
+	Errors      int
+}
+
+// Fetcher fetches URLs on behalf of a crawl, applying robots.txt rules,
+// per-host rate limiting, HEAD-first probing, and conditional GETs.
+type Fetcher struct {
+	Client       *http.Client
+	UserAgent    string
+	Robots       *RobotsCache
+	Limiter      *HostLimiter
+	Cache        CacheStore
+	MaxBodyBytes int64
+	HTMLOnly     bool
+
+	statsMu sync.Mutex
+	stats   map[string]*HostStats
+}
+
+// New builds a Fetcher with reasonable defaults: 1 request/second per
+// host, a global concurrency cap of 4, and a 5MB HEAD-first threshold.
+func New(userAgent string, cache CacheStore) *Fetcher {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return &Fetcher{
+		Client:       client,
+		UserAgent:    userAgent,
+		Robots:       NewRobotsCache(client, time.Hour),
+		Limiter:      NewHostLimiter(1, 1, 4),
+		Cache:        cache,
+		MaxBodyBytes: 5 << 20,
+		HTMLOnly:     true,
+	}
+}
+
+// Fetch retrieves rawURL, honoring robots.txt, rate limits, and any
+// cached ETag/Last-Modified for conditional requests.
+func (f *Fetcher) Fetch(rawURL string) (*Result, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, crawlDelay := f.Robots.Allowed(u, f.UserAgent)
+	f.note(u.Host, func(s *HostStats) { s.Requests++ })
+	if !allowed {
+		f.note(u.Host, func(s *HostStats) { s.Disallowed++ })
+		return nil, ErrDisallowed
+	}
+	f.Limiter.SetCrawlDelay(u.Host, crawlDelay)
+
+	release := f.Limiter.Acquire(u.Host)
+	defer release()
+
+	if skip, headResp := f.probe(rawURL); skip {
+		f.note(u.Host, func(s *HostStats) { s.Skipped++ })
+		return &Result{URL: rawURL, StatusCode: headResp.StatusCode, Header: headResp.Header, Skipped: true}, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.UserAgent)
+	if f.Cache != nil {
+		if etag, lastMod, ok, _ := f.Cache.Get(rawURL); ok {
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lastMod != "" {
+				req.Header.Set("If-Modified-Since", lastMod)
+			}
+		}
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		f.note(u.Host, func(s *HostStats) { s.Errors++ })
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		f.note(u.Host, func(s *HostStats) { s.NotModified++ })
+		return &Result{URL: rawURL, StatusCode: resp.StatusCode, Header: resp.Header, NotModified: true}, nil
+	}
+
+	var body []byte
+	if f.MaxBodyBytes > 0 {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, f.MaxBodyBytes+1))
+		if err == nil && int64(len(body)) > f.MaxBodyBytes {
+			f.note(u.Host, func(s *HostStats) { s.Skipped++ })
+			return &Result{URL: rawURL, StatusCode: resp.StatusCode, Header: resp.Header, Skipped: true}, nil
+		}
+	} else {
+		body, err = io.ReadAll(resp.Body)
+	}
+	if err != nil {
+		f.note(u.Host, func(s *HostStats) { s.Errors++ })
+		return nil, err
+	}
+	if f.Cache != nil {
+		f.Cache.Set(rawURL, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	}
+	f.note(u.Host, func(s *HostStats) { s.Fetched++ })
+	return &Result{URL: rawURL, StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// probe issues a HEAD request and reports whether the body should be
+// skipped, based on declared size and content type. A response with no
+// declared Content-Length (e.g. chunked transfer-encoding) can't be
+// sized here; the GET path still enforces MaxBodyBytes itself once the
+// body is actually read.
+func (f *Fetcher) probe(rawURL string) (bool, *http.Response) {
+	req, err := http.NewRequest(http.MethodHead, rawURL, nil)
+	if err != nil {
+		return false, nil
+	}
+	req.Header.Set("User-Agent", f.UserAgent)
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return false, nil
+	}
+	resp.Body.Close()
+
+	if f.MaxBodyBytes > 0 && resp.ContentLength > f.MaxBodyBytes {
+		return true, resp
+	}
+	if f.HTMLOnly {
+		ct := resp.Header.Get("Content-Type")
+		if ct != "" && !strings.Contains(strings.ToLower(ct), "html") {
+			return true, resp
+		}
+	}
+	return false, nil
+}
+
+// Stats returns a snapshot of per-host counters collected so far.
+func (f *Fetcher) Stats() map[string]HostStats {
+	f.statsMu.Lock()
+	defer f.statsMu.Unlock()
+	out := make(map[string]HostStats, len(f.stats))
+	for host, s := range f.stats {
+		out[host] = *s
+	}
+	return out
+}
+
+func (f *Fetcher) note(host string, update func(*HostStats)) {
+	f.statsMu.Lock()
+	defer f.statsMu.Unlock()
+	if f.stats == nil {
+		f.stats = make(map[string]*HostStats)
+	}
+	s, ok := f.stats[host]
+	if !ok {
+		s = &HostStats{}
+		f.stats[host] = s
+	}
+	update(s)
+}
@@ -0,0 +1,96 @@
+package fetcher
+
+import (
+	"sync"
+	"time"
+)
+
+// HostLimiter enforces a token-bucket rate limit per host plus a global
+// concurrency cap shared across all hosts.
+type HostLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	defaultRPS  float64
+	burst       float64
+	concurrency chan struct{}
+}
+
+type tokenBucket struct {
+	tokens float64
+	rps    float64
+	burst  float64
+	last   time.Time
+}
+
+// NewHostLimiter allows defaultRPS requests per second per host (burst
+// tokens banked up front), and caps the number of in-flight requests
+// across all hosts to globalConcurrency.
+func NewHostLimiter(defaultRPS float64, burst int, globalConcurrency int) *HostLimiter {
+	return &HostLimiter{
+		buckets:     make(map[string]*tokenBucket),
+		defaultRPS:  defaultRPS,
+		burst:       float64(burst),
+		concurrency: make(chan struct{}, globalConcurrency),
+	}
+}
+
+// SetCrawlDelay narrows a host's effective rate if needed so it never
+// exceeds the Crawl-delay the host's robots.txt requested.
+func (l *HostLimiter) SetCrawlDelay(host string, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+	rps := 1 / delay.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucketFor(host)
+	if rps < b.rps {
+		b.rps = rps
+	}
+}
+
+// Acquire blocks until host is allowed another request under both the
+// per-host rate limit and the global concurrency cap, then returns a
+// func to release the concurrency slot once the request completes.
+func (l *HostLimiter) Acquire(host string) func() {
+	l.concurrency <- struct{}{}
+
+	l.mu.Lock()
+	wait := l.bucketFor(host).reserve()
+	l.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return func() { <-l.concurrency }
+}
+
+func (l *HostLimiter) bucketFor(host string) *tokenBucket {
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, rps: l.defaultRPS, burst: l.burst, last: time.Now()}
+		l.buckets[host] = b
+	}
+	return b
+}
+
+// reserve consumes one token, returning how long the caller must wait
+// before it's safe to proceed.
+func (b *tokenBucket) reserve() time.Duration {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rps
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	need := 1 - b.tokens
+	wait := time.Duration(need / b.rps * float64(time.Second))
+	b.tokens--
+	return wait
+}
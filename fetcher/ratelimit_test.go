@@ -0,0 +1,47 @@
+package fetcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	b := &tokenBucket{tokens: 2, rps: 1, burst: 2, last: time.Now()}
+
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("first reserve with tokens available: wait = %v, want 0", wait)
+	}
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("second reserve draining the burst: wait = %v, want 0", wait)
+	}
+
+	wait := b.reserve()
+	if wait <= 0 {
+		t.Errorf("reserve with an empty bucket should require a wait, got %v", wait)
+	}
+	if wait > time.Second {
+		t.Errorf("reserve wait = %v, want roughly <= 1s at 1 rps", wait)
+	}
+}
+
+func TestTokenBucketReserveRefillsOverTime(t *testing.T) {
+	b := &tokenBucket{tokens: 0, rps: 10, burst: 1, last: time.Now().Add(-200 * time.Millisecond)}
+
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("reserve after enough elapsed time should not wait, got %v", wait)
+	}
+}
+
+func TestTokenBucketReserveCapsAtBurst(t *testing.T) {
+	// A long idle gap would refill far more than burst tokens' worth;
+	// reserve must cap accumulation at burst rather than letting it bank
+	// up unboundedly.
+	b := &tokenBucket{tokens: 1, rps: 100, burst: 1, last: time.Now().Add(-time.Second)}
+
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("first reserve should be free, got wait = %v", wait)
+	}
+	if wait := b.reserve(); wait == 0 {
+		t.Errorf("second immediate reserve should require a wait since accumulation is capped at burst, got 0")
+	}
+}
@@ -0,0 +1,180 @@
+package fetcher
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type robotsRules struct {
+	disallow   []string
+	allow      []string
+	crawlDelay time.Duration
+}
+
+// allows reports whether path is permitted, using longest-match-wins
+// between the Allow and Disallow rule sets, per the de-facto robots.txt
+// convention.
+func (r *robotsRules) allows(path string) bool {
+	allowMatch, disallowMatch := -1, -1
+	for _, p := range r.allow {
+		if p != "" && strings.HasPrefix(path, p) && len(p) > allowMatch {
+			allowMatch = len(p)
+		}
+	}
+	for _, p := range r.disallow {
+		if p != "" && strings.HasPrefix(path, p) && len(p) > disallowMatch {
+			disallowMatch = len(p)
+		}
+	}
+	return disallowMatch <= allowMatch
+}
+
+// RobotsCache fetches and caches /robots.txt per host, so repeated
+// lookups for the same host don't re-fetch the file.
+type RobotsCache struct {
+	mu     sync.Mutex
+	client *http.Client
+	ttl    time.Duration
+	cache  map[string]robotsCacheEntry
+}
+
+type robotsCacheEntry struct {
+	fetchedAt time.Time
+	groups    map[string]*robotsRules
+}
+
+// NewRobotsCache returns a cache that re-fetches a host's robots.txt
+// after ttl has elapsed.
+func NewRobotsCache(client *http.Client, ttl time.Duration) *RobotsCache {
+	return &RobotsCache{client: client, ttl: ttl, cache: make(map[string]robotsCacheEntry)}
+}
+
+// Allowed reports whether u may be fetched by userAgent, and the
+// Crawl-delay (if any) the site requests for that agent.
+func (c *RobotsCache) Allowed(u *url.URL, userAgent string) (bool, time.Duration) {
+	groups, err := c.groupsFor(u)
+	if err != nil {
+		// Fail open: an unreachable robots.txt does not block the crawl.
+		return true, 0
+	}
+	rules := selectGroup(groups, userAgent)
+	if rules == nil {
+		return true, 0
+	}
+	return rules.allows(u.Path), rules.crawlDelay
+}
+
+func (c *RobotsCache) groupsFor(u *url.URL) (map[string]*robotsRules, error) {
+	key := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.groups, nil
+	}
+
+	resp, err := c.client.Get(key + "/robots.txt")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var groups map[string]*robotsRules
+	if resp.StatusCode == http.StatusOK {
+		groups = parseRobots(resp.Body)
+	} else {
+		// Any non-200 (404, 5xx, ...) means no restrictions apply.
+		groups = map[string]*robotsRules{}
+	}
+
+	c.mu.Lock()
+	c.cache[key] = robotsCacheEntry{fetchedAt: time.Now(), groups: groups}
+	c.mu.Unlock()
+
+	return groups, nil
+}
+
+// selectGroup picks the rules of a group whose name appears anywhere in
+// userAgent (case-insensitively), falling back to the wildcard "*" group
+// when no group name matches.
+func selectGroup(groups map[string]*robotsRules, userAgent string) *robotsRules {
+	ua := strings.ToLower(userAgent)
+	for name, rules := range groups {
+		if name != "*" && strings.Contains(ua, name) {
+			return rules
+		}
+	}
+	return groups["*"]
+}
+
+// parseRobots does a minimal line-oriented parse of a robots.txt body
+// into per-agent rule groups.
+func parseRobots(body io.Reader) map[string]*robotsRules {
+	groups := make(map[string]*robotsRules)
+	var current []string
+	sawDirective := false
+
+	ensure := func(name string) *robotsRules {
+		if groups[name] == nil {
+			groups[name] = &robotsRules{}
+		}
+		return groups[name]
+	}
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+		if i := strings.Index(value, "#"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+
+		switch field {
+		case "user-agent":
+			name := strings.ToLower(value)
+			if sawDirective {
+				// A User-agent line after directives starts a new group;
+				// consecutive User-agent lines share the group that follows.
+				current = nil
+				sawDirective = false
+			}
+			current = append(current, name)
+		case "disallow":
+			sawDirective = true
+			for _, name := range current {
+				ensure(name).disallow = append(ensure(name).disallow, value)
+			}
+		case "allow":
+			sawDirective = true
+			for _, name := range current {
+				ensure(name).allow = append(ensure(name).allow, value)
+			}
+		case "crawl-delay":
+			sawDirective = true
+			if secs, err := strconv.ParseFloat(value, 64); err == nil {
+				d := time.Duration(secs * float64(time.Second))
+				for _, name := range current {
+					ensure(name).crawlDelay = d
+				}
+			}
+		default:
+			continue
+		}
+	}
+	return groups
+}
@@ -0,0 +1,118 @@
+package fetcher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRobotsRulesAllows(t *testing.T) {
+	tests := []struct {
+		name     string
+		disallow []string
+		allow    []string
+		path     string
+		want     bool
+	}{
+		{
+			name: "no rules allows everything",
+			path: "/anything",
+			want: true,
+		},
+		{
+			name:     "disallow prefix blocks",
+			disallow: []string{"/private"},
+			path:     "/private/page",
+			want:     false,
+		},
+		{
+			name:     "unmatched disallow prefix does not block",
+			disallow: []string{"/private"},
+			path:     "/public/page",
+			want:     true,
+		},
+		{
+			name:     "longer allow overrides shorter disallow",
+			disallow: []string{"/private"},
+			allow:    []string{"/private/public"},
+			path:     "/private/public/page",
+			want:     true,
+		},
+		{
+			name:     "longer disallow overrides shorter allow",
+			disallow: []string{"/private/secret"},
+			allow:    []string{"/private"},
+			path:     "/private/secret/page",
+			want:     false,
+		},
+		{
+			name:     "equal-length allow and disallow favors allow",
+			disallow: []string{"/private"},
+			allow:    []string{"/private"},
+			path:     "/private/page",
+			want:     true,
+		},
+		{
+			name:     "empty-string rule is ignored",
+			disallow: []string{""},
+			path:     "/page",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &robotsRules{disallow: tt.disallow, allow: tt.allow}
+			if got := r.allows(tt.path); got != tt.want {
+				t.Errorf("allows(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectGroup(t *testing.T) {
+	wildcard := &robotsRules{disallow: []string{"/wild"}}
+	specific := &robotsRules{disallow: []string{"/specific"}}
+	groups := map[string]*robotsRules{
+		"*":       wildcard,
+		"mycrawl": specific,
+	}
+
+	if got := selectGroup(groups, "MyCrawlBot/1.0"); got != specific {
+		t.Errorf("selectGroup matched %v, want the mycrawl group", got)
+	}
+	if got := selectGroup(groups, "SomeOtherBot/1.0"); got != wildcard {
+		t.Errorf("selectGroup matched %v, want the wildcard group", got)
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	body := `
+User-agent: *
+Disallow: /private
+Crawl-delay: 2
+
+User-agent: mycrawl
+Disallow: /private
+Allow: /private/public
+`
+	groups := parseRobots(strings.NewReader(body))
+
+	wildcard, ok := groups["*"]
+	if !ok {
+		t.Fatalf("expected a wildcard group")
+	}
+	if wildcard.crawlDelay.Seconds() != 2 {
+		t.Errorf("wildcard crawlDelay = %v, want 2s", wildcard.crawlDelay)
+	}
+	if !wildcard.allows("/public") || wildcard.allows("/private/page") {
+		t.Errorf("wildcard rules did not match expected allow/disallow")
+	}
+
+	mine, ok := groups["mycrawl"]
+	if !ok {
+		t.Fatalf("expected a mycrawl group")
+	}
+	if !mine.allows("/private/public/page") {
+		t.Errorf("mycrawl rules should allow /private/public/page via the longer Allow match")
+	}
+}
@@ -0,0 +1,62 @@
+package jobs
+
+const (
+	defaultUserAgent         = "webcrawler/1.0"
+	defaultRequestsPerSecond = 1.0
+	defaultConcurrency       = 4
+	defaultMaxDepth          = 2
+)
+
+// SeedsConfig describes where a job's seed URLs come from: a flat list
+// (typically parsed from an uploaded CSV) and/or a sitemap.xml (or
+// sitemap index) to crawl in addition to it.
+type SeedsConfig struct {
+	URLs    []string `json:"urls"`
+	Sitemap string   `json:"sitemap"`
+}
+
+// ScopeConfig describes the crawl scope applied to every seed: which
+// schemes to follow, whether to stay on the seed's host, a required
+// path prefix, and regexes to exclude. It is the config-shaped
+// counterpart of crawler.Scope.
+type ScopeConfig struct {
+	AllowedSchemes []string `json:"allowedSchemes"`
+	SameHost       *bool    `json:"sameHost"`
+	PathPrefix     string   `json:"pathPrefix"`
+	Exclude        []string `json:"exclude"`
+}
+
+// Config describes one crawl run: its seeds and the politeness/scope/
+// check settings to run it with. It is the shape accepted by both the
+// REST API and the websocket upload form.
+type Config struct {
+	Seeds             SeedsConfig `json:"seeds"`
+	Scope             ScopeConfig `json:"scope"`
+	UserAgent         string      `json:"userAgent"`
+	RequestsPerSecond float64     `json:"requestsPerSecond"`
+	Concurrency       int         `json:"concurrency"`
+	MaxDepth          int         `json:"maxDepth"`
+	RulesConfig       string      `json:"rulesConfig"`
+}
+
+func (c *Config) applyDefaults() {
+	if c.UserAgent == "" {
+		c.UserAgent = defaultUserAgent
+	}
+	if c.RequestsPerSecond <= 0 {
+		c.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultConcurrency
+	}
+	if c.MaxDepth <= 0 {
+		c.MaxDepth = defaultMaxDepth
+	}
+	if len(c.Scope.AllowedSchemes) == 0 {
+		c.Scope.AllowedSchemes = []string{"http", "https"}
+	}
+	if c.Scope.SameHost == nil {
+		sameHost := true
+		c.Scope.SameHost = &sameHost
+	}
+}
@@ -0,0 +1,246 @@
+package jobs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"webcrawler/checks"
+	"webcrawler/crawler"
+	"webcrawler/fetcher"
+	"webcrawler/seeds"
+)
+
+// pageResult is one line of the job's NDJSON results stream.
+type pageResult struct {
+	URL         string           `json:"url"`
+	Depth       int              `json:"depth"`
+	Title       string           `json:"title,omitempty"`
+	StatusCode  int              `json:"statusCode"`
+	LoadTimeMs  int64            `json:"loadTimeMs"`
+	NotModified bool             `json:"notModified,omitempty"`
+	Skipped     bool             `json:"skipped,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	Findings    []checks.Finding `json:"findings,omitempty"`
+}
+
+// pageFindings is the per-page shape written to the JSON findings report.
+type pageFindings struct {
+	URL      string           `json:"url"`
+	Findings []checks.Finding `json:"findings"`
+}
+
+// execute runs the crawl for job.Config, writing all of its output
+// under job.Dir: a visited-URL store, a WARC archive, a streaming
+// results.jsonl, a final output.csv, and a findings.json report.
+func (j *Job) execute() error {
+	store, err := crawler.OpenStore(filepath.Join(j.Dir, "visited.db"))
+	if err != nil {
+		return fmt.Errorf("opening visited-URL store: %w", err)
+	}
+	defer store.Close()
+
+	warcFile, err := os.Create(filepath.Join(j.Dir, "output.warc"))
+	if err != nil {
+		return fmt.Errorf("creating WARC file: %w", err)
+	}
+	defer warcFile.Close()
+
+	resultsFile, err := os.Create(filepath.Join(j.Dir, "results.jsonl"))
+	if err != nil {
+		return fmt.Errorf("creating results.jsonl: %w", err)
+	}
+	defer resultsFile.Close()
+
+	seedURLs := append([]string{}, j.Config.Seeds.URLs...)
+	var sitemapLoader *seeds.Loader
+	sitemapLastMod := map[string]string{}
+	if j.Config.Seeds.Sitemap != "" {
+		sitemapLoader = seeds.NewLoader(j.Config.UserAgent, store)
+		sitemapURLs, err := sitemapLoader.Load(j.Config.Seeds.Sitemap)
+		if err != nil {
+			j.progress(fmt.Sprintf("Error loading sitemap %s: %v", j.Config.Seeds.Sitemap, err))
+		} else {
+			j.progress(fmt.Sprintf("Loaded %d URL(s) from sitemap %s", len(sitemapURLs), j.Config.Seeds.Sitemap))
+			for _, s := range sitemapURLs {
+				seedURLs = append(seedURLs, s.URL)
+				if s.LastMod != "" {
+					sitemapLastMod[s.URL] = s.LastMod
+				}
+			}
+		}
+	}
+
+	scope, err := crawler.NewScope(j.Config.Scope.AllowedSchemes, *j.Config.Scope.SameHost, j.Config.Scope.PathPrefix, j.Config.Scope.Exclude)
+	if err != nil {
+		return fmt.Errorf("building scope: %w", err)
+	}
+
+	crawlSeeds := make([]crawler.Seed, 0, len(seedURLs))
+	for _, u := range seedURLs {
+		crawlSeeds = append(crawlSeeds, crawler.Seed{URL: u, Scope: scope})
+	}
+
+	ruleConfig, err := checks.ParseConfig([]byte(j.Config.RulesConfig))
+	if err != nil {
+		j.progress(fmt.Sprintf("Error parsing rule config, running with defaults: %v", err))
+		ruleConfig = checks.RuleConfig{}
+	}
+	registry := checks.NewDefaultRegistry()
+	ignoreClasses := ruleConfig.IgnoreClassSet()
+
+	var docsMu sync.Mutex
+	pageDocs := make(map[string]*goquery.Document)
+
+	f := fetcher.New(j.Config.UserAgent, store)
+	f.Limiter = fetcher.NewHostLimiter(j.Config.RequestsPerSecond, 1, j.Config.Concurrency)
+
+	var resultsMu sync.Mutex
+	encoder := json.NewEncoder(resultsFile)
+
+	c := &crawler.Crawler{
+		Seeds:       crawlSeeds,
+		MaxDepth:    j.Config.MaxDepth,
+		Concurrency: j.Config.Concurrency,
+		Store:       store,
+		Warc:        crawler.NewWarcWriter(warcFile),
+		Fetcher:     f,
+		OnPage: func(pageURL string, doc *goquery.Document) {
+			docsMu.Lock()
+			pageDocs[pageURL] = doc
+			docsMu.Unlock()
+		},
+		OnResult: func(page crawler.Page) {
+			j.incFetched()
+			j.progress(fmt.Sprintf("Fetched: %s (%d)", page.URL, page.StatusCode))
+
+			result := pageResult{
+				URL:         page.URL,
+				Depth:       page.Depth,
+				Title:       page.Title,
+				StatusCode:  page.StatusCode,
+				LoadTimeMs:  page.LoadTime.Milliseconds(),
+				NotModified: page.NotModified,
+				Skipped:     page.Skipped,
+			}
+			if page.Err != nil {
+				result.Error = page.Err.Error()
+			} else if lastMod, ok := sitemapLastMod[page.URL]; ok {
+				sitemapLoader.MarkFetched(page.URL, lastMod)
+			}
+
+			resultsMu.Lock()
+			encoder.Encode(result)
+			resultsFile.Sync()
+			resultsMu.Unlock()
+		},
+	}
+
+	j.progress(fmt.Sprintf("Crawling %d seed(s), max depth %d", len(crawlSeeds), j.Config.MaxDepth))
+	pages, err := c.Run()
+	if err != nil {
+		return fmt.Errorf("running crawl: %w", err)
+	}
+
+	if statsJSON, err := json.Marshal(f.Stats()); err == nil {
+		j.setStatsJSON(statsJSON)
+	}
+
+	statusByURL := make(map[string]int, len(pages))
+	for _, page := range pages {
+		statusByURL[page.URL] = page.StatusCode
+	}
+	resolveStatus := func(url string) (int, bool) {
+		status, ok := statusByURL[url]
+		return status, ok
+	}
+
+	pageInfos := make([]pageResult, 0, len(pages))
+	for _, page := range pages {
+		info := pageResult{
+			URL:        page.URL,
+			Depth:      page.Depth,
+			Title:      page.Title,
+			StatusCode: page.StatusCode,
+			LoadTimeMs: page.LoadTime.Milliseconds(),
+		}
+		if page.Err != nil {
+			info.Error = page.Err.Error()
+		} else if doc, ok := pageDocs[page.URL]; ok {
+			ctx := &checks.PageContext{URL: page.URL, Doc: doc, IgnoreClasses: ignoreClasses, ResolveStatus: resolveStatus}
+			findings, err := registry.Run(ctx, ruleConfig.Checks)
+			if err != nil {
+				j.progress(fmt.Sprintf("Error running checks for %s: %v", page.URL, err))
+			}
+			info.Findings = findings
+		}
+		pageInfos = append(pageInfos, info)
+	}
+
+	if err := writeCSV(filepath.Join(j.Dir, "output.csv"), pageInfos); err != nil {
+		return fmt.Errorf("writing output.csv: %w", err)
+	}
+	if err := writeFindingsReport(filepath.Join(j.Dir, "findings.json"), pageInfos); err != nil {
+		return fmt.Errorf("writing findings.json: %w", err)
+	}
+
+	j.progress("Processing completed")
+	return nil
+}
+
+func writeCSV(path string, pageInfos []pageResult) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := csv.NewWriter(out)
+	defer writer.Flush()
+
+	writer.Write([]string{"URL", "Title", "Status Code", "Load Time (ms)", "Findings Count", "Findings"})
+	for _, p := range pageInfos {
+		if err := writer.Write([]string{
+			p.URL,
+			p.Title,
+			fmt.Sprintf("%d", p.StatusCode),
+			fmt.Sprintf("%d", p.LoadTimeMs),
+			fmt.Sprintf("%d", len(p.Findings)),
+			formatFindings(p.Findings),
+		}); err != nil {
+			return err
+		}
+	}
+	return writer.Error()
+}
+
+func formatFindings(findings []checks.Finding) string {
+	parts := make([]string, 0, len(findings))
+	for _, f := range findings {
+		if f.Detail == "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", f.Check, f.Message))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s: %s (%s)", f.Check, f.Message, f.Detail))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func writeFindingsReport(path string, pageInfos []pageResult) error {
+	report := make([]pageFindings, 0, len(pageInfos))
+	for _, p := range pageInfos {
+		report = append(report, pageFindings{URL: p.URL, Findings: p.Findings})
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,228 @@
+// Package jobs runs crawls as job-scoped units of work so the REST API
+// and the websocket upload form can share one engine: each job gets its
+// own output directory, so concurrent runs never clobber each other's
+// output.csv or visited-URL store.
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultRetention is how long a finished job (and its output
+// directory) is kept around before Manager evicts it, absent an
+// explicit Retention.
+const defaultRetention = time.Hour
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Job is one crawl run, identified by ID, with its output confined to Dir.
+type Job struct {
+	ID     string
+	Dir    string
+	Config Config
+
+	// OnProgress, if set before the job starts, is called with
+	// human-readable progress lines. Used by the websocket adapter to
+	// relay progress the way it always has; the REST API leaves it nil
+	// and callers poll GET /api/jobs/{id} instead.
+	OnProgress func(string)
+
+	mu        sync.Mutex
+	status    Status
+	fetched   int
+	err       error
+	statsJSON []byte
+	done      chan struct{}
+}
+
+// Snapshot is the JSON-serializable view of a Job's current state.
+type Snapshot struct {
+	ID      string `json:"id"`
+	Status  Status `json:"status"`
+	Fetched int    `json:"fetched"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Snapshot returns the job's current state.
+func (j *Job) Snapshot() Snapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := Snapshot{ID: j.ID, Status: j.status, Fetched: j.fetched}
+	if j.err != nil {
+		s.Error = j.err.Error()
+	}
+	return s
+}
+
+// Wait blocks until the job has finished (successfully or not).
+func (j *Job) Wait() {
+	<-j.done
+}
+
+// Done returns a channel that's closed once the job has finished, for
+// callers that need to select on it alongside other events.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// StatsJSON returns the per-host fetch stats collected during the
+// crawl, already marshaled to JSON. It's empty until the job finishes.
+func (j *Job) StatsJSON() []byte {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.statsJSON
+}
+
+func (j *Job) setStatsJSON(b []byte) {
+	j.mu.Lock()
+	j.statsJSON = b
+	j.mu.Unlock()
+}
+
+func (j *Job) setStatus(s Status) {
+	j.mu.Lock()
+	j.status = s
+	j.mu.Unlock()
+}
+
+func (j *Job) incFetched() {
+	j.mu.Lock()
+	j.fetched++
+	j.mu.Unlock()
+}
+
+func (j *Job) progress(msg string) {
+	if j.OnProgress != nil {
+		j.OnProgress(msg)
+	}
+}
+
+// Manager creates and tracks Jobs, each under its own subdirectory of
+// BaseDir. So a long-lived server doesn't leak memory and disk as jobs
+// pile up, a finished job is evicted (dropped from the in-memory map and
+// its output directory removed) Retention after it completes or fails.
+type Manager struct {
+	BaseDir string
+
+	// Retention is how long a finished job's state and output
+	// directory are kept before Manager evicts it. Zero means
+	// defaultRetention; NewManager always sets it.
+	Retention time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewManager returns a Manager that stores job output under baseDir and
+// evicts finished jobs after defaultRetention.
+func NewManager(baseDir string) (*Manager, error) {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return nil, err
+	}
+	return &Manager{BaseDir: baseDir, Retention: defaultRetention, jobs: make(map[string]*Job)}, nil
+}
+
+// Submit creates a job for cfg and starts it in the background,
+// returning immediately with the job so its ID can be handed back to
+// the caller (e.g. as a REST response). onProgress may be nil; when
+// set, it's called with human-readable progress lines as the crawl
+// runs (used by the websocket adapter).
+func (m *Manager) Submit(cfg Config, onProgress func(string)) (*Job, error) {
+	cfg.applyDefaults()
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(m.BaseDir, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	job := &Job{ID: id, Dir: dir, Config: cfg, status: StatusPending, done: make(chan struct{}), OnProgress: onProgress}
+
+	m.mu.Lock()
+	m.jobs[id] = job
+	m.mu.Unlock()
+
+	go m.run(job)
+	return job, nil
+}
+
+// Get returns the job with the given ID, if it exists.
+func (m *Manager) Get(id string) (*Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	return j, ok
+}
+
+func (m *Manager) run(job *Job) {
+	job.setStatus(StatusRunning)
+	err := runJob(job)
+
+	job.mu.Lock()
+	if err != nil {
+		job.status = StatusFailed
+		job.err = err
+	} else {
+		job.status = StatusCompleted
+	}
+	job.mu.Unlock()
+
+	close(job.done)
+	retention := m.Retention
+	if retention == 0 {
+		retention = defaultRetention
+	}
+	time.AfterFunc(retention, func() { m.evict(job.ID) })
+}
+
+// runJob runs job.execute, recovering from any panic so that a bug in a
+// single job (a malformed page tripping a nil-pointer in goquery, say,
+// or a bug in a Check) fails just that job instead of taking down the
+// server and every other job running alongside it.
+func runJob(job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job.execute()
+}
+
+// evict drops id from the tracked jobs and removes its output directory.
+// Called once Retention has elapsed after a job finishes.
+func (m *Manager) evict(id string) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	if ok {
+		delete(m.jobs, id)
+	}
+	m.mu.Unlock()
+	if ok {
+		os.RemoveAll(job.Dir)
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(filepath.Join(t.TempDir(), "jobs"))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m
+}
+
+func TestManagerSubmitRunsJobToCompletion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Home</title></head><body>hi</body></html>`))
+	}))
+	defer srv.Close()
+
+	m := newTestManager(t)
+	job, err := m.Submit(Config{Seeds: SeedsConfig{URLs: []string{srv.URL + "/"}}}, nil)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	select {
+	case <-job.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("job did not finish in time")
+	}
+
+	snap := job.Snapshot()
+	if snap.Status != StatusCompleted {
+		t.Fatalf("status = %v, want %v (error: %s)", snap.Status, StatusCompleted, snap.Error)
+	}
+	if snap.Fetched != 1 {
+		t.Errorf("fetched = %d, want 1", snap.Fetched)
+	}
+
+	if _, err := os.Stat(filepath.Join(job.Dir, "output.csv")); err != nil {
+		t.Errorf("expected output.csv to exist: %v", err)
+	}
+}
+
+func TestManagerEvictsFinishedJobAfterRetention(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html></html>`))
+	}))
+	defer srv.Close()
+
+	m := newTestManager(t)
+	m.Retention = 20 * time.Millisecond
+
+	job, err := m.Submit(Config{Seeds: SeedsConfig{URLs: []string{srv.URL + "/"}}}, nil)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	job.Wait()
+
+	if _, ok := m.Get(job.ID); !ok {
+		t.Fatalf("job should still be tracked immediately after finishing")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := m.Get(job.ID); !ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, ok := m.Get(job.ID); ok {
+		t.Fatalf("job was not evicted after Retention elapsed")
+	}
+	if _, err := os.Stat(job.Dir); !os.IsNotExist(err) {
+		t.Errorf("expected job.Dir to be removed on eviction, stat err: %v", err)
+	}
+}
+
+// TestRunJobRecoversFromPanic exercises runJob directly against a Job
+// whose Config never went through applyDefaults (Scope.SameHost is nil,
+// as Manager.Submit would otherwise guarantee), which panics inside
+// execute on the pointer dereference. runJob must recover and report it
+// as a failed job rather than crash the process.
+func TestRunJobRecoversFromPanic(t *testing.T) {
+	job := &Job{ID: "panics", Dir: t.TempDir(), status: StatusRunning, done: make(chan struct{})}
+
+	if err := runJob(job); err == nil {
+		t.Fatal("expected runJob to return an error after recovering from the panic")
+	}
+}
@@ -3,26 +3,18 @@ package main
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/gorilla/websocket"
-)
 
-type PageInfo struct {
-	URL                string
-	Title              string
-	StatusCode         int
-	LoadTime           time.Duration
-	SelfReferencingURL bool
-	AnchorDetails      string
-}
+	"webcrawler/jobs"
+)
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
@@ -34,45 +26,6 @@ func uploadHandler(w http.ResponseWriter, r *http.Request) {
 	t.Execute(w, nil)
 }
 
-// List of classes to ignore
-var ignoredClasses = map[string]bool{
-	"footer__copy-logo":                  true,
-	"header__upper-link":                 true,
-	"mp-share__toggle":                   true,
-	"mp-share__toggle  breadcrumbs__tag": true,
-	"mp-link mp-link--dark localisation-toggle localisation-setter": true,
-	"hash-scroll":                       true,
-	"mp-available-session__show-detail": true,
-	"mp-available-session__hide-detail": true,
-	// Add more classes as needed
-}
-
-// Function to check for self-referencing links with href="#" and ignore specified classes
-func checkSelfReferencingLinks(doc *goquery.Document) (bool, string) {
-	found := false
-	var anchorDetails []string
-
-	doc.Find("a").Each(func(i int, s *goquery.Selection) {
-		href, _ := s.Attr("href")
-		class, _ := s.Attr("class")
-
-		// Check if the href is exactly "#" and class is not in ignored classes
-		if href == "#" && !ignoredClasses[class] {
-			title := s.AttrOr("title", "No title")
-			anchor := fmt.Sprintf("<a href=\"%s\" class=\"%s\" title=\"%s\">", href, class, title)
-			anchorDetails = append(anchorDetails, anchor)
-			found = true
-			fmt.Printf("Self-referencing link found (not ignored class):\n")
-			fmt.Printf("URL: %s\n", href)
-			fmt.Printf("Class: %s\n", class)
-			fmt.Printf("Title: %s\n", title)
-			fmt.Println("-----")
-		}
-	})
-
-	return found, strings.Join(anchorDetails, ",")
-}
-
 func removeBOM(s string) string {
 	bom := []byte{0xEF, 0xBB, 0xBF}
 	if bytes.HasPrefix([]byte(s), bom) {
@@ -81,131 +34,100 @@ func removeBOM(s string) string {
 	return s
 }
 
-func wsHandler(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		http.Error(w, "Could not open websocket connection", http.StatusBadRequest)
-		return
-	}
-	defer conn.Close()
-
-	_, msg, err := conn.ReadMessage()
-	if err != nil {
-		fmt.Println("Error reading message:", err)
-		return
-	}
+// seedsFromCSV reads one seed URL per row from the first column of a CSV
+// file, skipping blank rows.
+func seedsFromCSV(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
 
-	file, err := os.CreateTemp("", "upload-*.csv")
-	if err != nil {
-		fmt.Println("Error creating temp file:", err)
-		return
-	}
-	defer os.Remove(file.Name())
-	defer file.Close()
-
-	if _, err := file.Write(msg); err != nil {
-		fmt.Println("Error writing to temp file:", err)
-		return
-	}
-
-	file.Seek(0, 0)
-	reader := csv.NewReader(file)
-
-	var pageInfos []PageInfo
 	var urls []string
-
 	for {
 		record, err := reader.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			fmt.Println("Error reading CSV file:", err)
-			conn.WriteMessage(websocket.TextMessage, []byte("Error reading CSV file"))
-			return
+			return nil, err
 		}
 
 		url := removeBOM(strings.TrimSpace(record[0]))
 		if url == "" {
-			fmt.Println("Empty URL found, skipping.")
 			continue
 		}
-
-		fmt.Printf("Processing URL: %s\n", url)
 		urls = append(urls, url)
 	}
+	return urls, nil
+}
 
-	totalUrls := len(urls)
-	for i, url := range urls {
-		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Processing: %s", url)))
-
-		start := time.Now()
-		resp, err := http.Get(url)
-		if err != nil {
-			pageInfos = append(pageInfos, PageInfo{URL: url, Title: "Error", StatusCode: 0, LoadTime: 0})
-			fmt.Printf("Error fetching URL: %s, error: %v\n", url, err)
-			continue
-		}
-		defer resp.Body.Close()
+// wsHandler is a thin adapter over jobManager: it reads the same
+// config+CSV payload the upload form has always sent, submits a job,
+// and relays progress and the final download link over the websocket
+// the way it always has.
+func wsHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		http.Error(w, "Could not open websocket connection", http.StatusBadRequest)
+		return
+	}
+	defer conn.Close()
 
-		loadTime := time.Since(start)
-		doc, err := goquery.NewDocumentFromReader(resp.Body)
-		if err != nil {
-			pageInfos = append(pageInfos, PageInfo{URL: url, Title: "Error", StatusCode: resp.StatusCode, LoadTime: loadTime})
-			fmt.Printf("Error parsing HTML for URL: %s, error: %v\n", url, err)
-			continue
-		}
+	_, configMsg, err := conn.ReadMessage()
+	if err != nil {
+		fmt.Println("Error reading config message:", err)
+		return
+	}
+	var cfg jobs.Config
+	if err := json.Unmarshal(configMsg, &cfg); err != nil {
+		fmt.Println("Error parsing crawl config, using defaults:", err)
+	}
 
-		title := doc.Find("title").Text()
-		selfReferencingURL, anchorDetails := checkSelfReferencingLinks(doc)
-		pageInfos = append(pageInfos, PageInfo{
-			URL:                url,
-			Title:              title,
-			StatusCode:         resp.StatusCode,
-			LoadTime:           loadTime,
-			SelfReferencingURL: selfReferencingURL,
-			AnchorDetails:      anchorDetails,
-		})
-
-		progress := int(float64(i+1) / float64(totalUrls) * 100)
-		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Progress: %d%%", progress)))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		fmt.Println("Error reading message:", err)
+		return
 	}
 
-	outputFileName := "output.csv"
-	outputFile, err := os.Create(outputFileName)
+	urls, err := seedsFromCSV(bytes.NewReader(msg))
 	if err != nil {
-		conn.WriteMessage(websocket.TextMessage, []byte("Error creating output file"))
+		fmt.Println("Error reading CSV file:", err)
+		conn.WriteMessage(websocket.TextMessage, []byte("Error reading CSV file"))
 		return
 	}
-	defer outputFile.Close()
-
-	writer := csv.NewWriter(outputFile)
-	defer writer.Flush()
-
-	writer.Write([]string{"URL", "Title", "Status Code", "Load Time (ms)", "Self-Referencing URL with #", "Anchor Details"})
-	for _, pageInfo := range pageInfos {
-		writer.Write([]string{
-			pageInfo.URL,
-			pageInfo.Title,
-			fmt.Sprintf("%d", pageInfo.StatusCode),
-			fmt.Sprintf("%d", pageInfo.LoadTime.Milliseconds()),
-			fmt.Sprintf("%t", pageInfo.SelfReferencingURL),
-			pageInfo.AnchorDetails,
-		})
+	cfg.Seeds.URLs = urls
+
+	job, err := jobManager.Submit(cfg, func(line string) {
+		conn.WriteMessage(websocket.TextMessage, []byte(line))
+	})
+	if err != nil {
+		fmt.Println("Error starting job:", err)
+		conn.WriteMessage(websocket.TextMessage, []byte("Error starting job"))
+		return
 	}
 
-	conn.WriteMessage(websocket.TextMessage, []byte("Processing completed"))
-	conn.WriteMessage(websocket.TextMessage, []byte("Download link: /download/output.csv"))
-}
+	job.Wait()
+	if snap := job.Snapshot(); snap.Error != "" {
+		conn.WriteMessage(websocket.TextMessage, []byte("Error running crawl: "+snap.Error))
+		return
+	}
 
-func downloadHandler(w http.ResponseWriter, r *http.Request) {
-	http.ServeFile(w, r, "output.csv")
+	if statsJSON := job.StatsJSON(); len(statsJSON) > 0 {
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Per-host stats: %s", statsJSON)))
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("Download link: /api/jobs/%s/results?format=csv", job.ID)))
 }
 
 func main() {
+	var err error
+	jobManager, err = jobs.NewManager("jobs")
+	if err != nil {
+		fmt.Println("Error creating job manager:", err)
+		os.Exit(1)
+	}
+
 	http.HandleFunc("/", uploadHandler)
 	http.HandleFunc("/upload", wsHandler)
-	http.HandleFunc("/download/output.csv", downloadHandler)
+	http.HandleFunc("POST /api/jobs", handleCreateJob)
+	http.HandleFunc("GET /api/jobs/{id}", handleJobStatus)
+	http.HandleFunc("GET /api/jobs/{id}/results", handleJobResults)
 
 	// Serve static files
 	fs := http.FileServer(http.Dir("static"))
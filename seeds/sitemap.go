@@ -0,0 +1,184 @@
+// Package seeds loads crawl seed URLs from sources other than a plain
+// CSV, currently sitemap.xml (and sitemap index) documents.
+package seeds
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"webcrawler/crawler"
+)
+
+const defaultMaxDepth = 5
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// Loader fetches and parses sitemap.xml / sitemap index documents into a
+// flat list of seed URLs, skipping entries whose <lastmod> matches what
+// was recorded on a prior run.
+type Loader struct {
+	Client    *http.Client
+	UserAgent string
+
+	// MaxDepth bounds how many levels of nested sitemap index a Load
+	// call will follow, guarding against misconfigured or cyclic
+	// sitemaps.
+	MaxDepth int
+
+	// Store, if set, is consulted (and updated) to skip sitemap
+	// entries whose <lastmod> hasn't changed since the last run. It's
+	// the same persistence layer used for conditional-GET caching.
+	Store *crawler.Store
+}
+
+// NewLoader builds a Loader with a 30s HTTP timeout and a default
+// nesting depth of 5.
+func NewLoader(userAgent string, store *crawler.Store) *Loader {
+	return &Loader{
+		Client:    &http.Client{Timeout: 30 * time.Second},
+		UserAgent: userAgent,
+		MaxDepth:  defaultMaxDepth,
+		Store:     store,
+	}
+}
+
+// SeedURL is a page URL discovered in a sitemap, together with the
+// <lastmod> value (if any) it was listed with.
+type SeedURL struct {
+	URL     string
+	LastMod string
+}
+
+// Load fetches sitemapURL and returns every page URL it (transitively)
+// references, following sitemap indexes up to MaxDepth. It records the
+// <lastmod> of each nested sitemap it loads successfully, but not of the
+// page URLs it returns; callers should do that via MarkFetched once a
+// returned URL has actually been crawled, so a
+// failed or interrupted run doesn't cause the URL to be silently
+// skipped as "unchanged" next time.
+func (l *Loader) Load(sitemapURL string) ([]SeedURL, error) {
+	return l.load(sitemapURL, 0)
+}
+
+func (l *Loader) load(sitemapURL string, depth int) ([]SeedURL, error) {
+	body, err := l.fetch(sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var index sitemapIndex
+	if err := xml.Unmarshal(body, &index); err == nil && len(index.Sitemaps) > 0 {
+		if depth >= l.MaxDepth {
+			return nil, fmt.Errorf("seeds: sitemap index nesting exceeds max depth %d at %s", l.MaxDepth, sitemapURL)
+		}
+
+		var urls []SeedURL
+		for _, ref := range index.Sitemaps {
+			if l.unchanged(ref.Loc, ref.LastMod) {
+				continue
+			}
+			childURLs, err := l.load(ref.Loc, depth+1)
+			if err != nil {
+				fmt.Printf("seeds: error loading nested sitemap %s: %v\n", ref.Loc, err)
+				continue
+			}
+			l.MarkFetched(ref.Loc, ref.LastMod)
+			urls = append(urls, childURLs...)
+		}
+		return urls, nil
+	}
+
+	var set urlSet
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("seeds: parsing sitemap %s: %w", sitemapURL, err)
+	}
+
+	urls := make([]SeedURL, 0, len(set.URLs))
+	for _, u := range set.URLs {
+		if u.Loc == "" || l.unchanged(u.Loc, u.LastMod) {
+			continue
+		}
+		urls = append(urls, SeedURL{URL: u.Loc, LastMod: u.LastMod})
+	}
+	return urls, nil
+}
+
+// unchanged reports whether lastMod matches what's recorded in Store for
+// url. A missing lastMod (or no Store) is always treated as changed. It
+// only reads from Store; use MarkFetched to record a new lastMod once
+// url has actually been processed.
+func (l *Loader) unchanged(url, lastMod string) bool {
+	if lastMod == "" || l.Store == nil {
+		return false
+	}
+	prev, ok, err := l.Store.SitemapLastMod(url)
+	if err != nil {
+		return false
+	}
+	return ok && prev == lastMod
+}
+
+// MarkFetched records lastMod as the last-seen <lastmod> for url. Callers
+// should only call this once url has been successfully fetched/processed
+// (or, for a nested sitemap index entry, successfully loaded), so that an
+// interrupted run can't cause it to be skipped as unchanged next time.
+func (l *Loader) MarkFetched(url, lastMod string) error {
+	if lastMod == "" || l.Store == nil {
+		return nil
+	}
+	return l.Store.SetSitemapLastMod(url, lastMod)
+}
+
+// fetch retrieves rawURL, transparently gunzipping it if it's a
+// gzipped sitemap (.xml.gz) not already decompressed by the transport.
+func (l *Loader) fetch(rawURL string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", l.UserAgent)
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("seeds: fetching %s: unexpected status %d", rawURL, resp.StatusCode)
+	}
+
+	var reader io.Reader = resp.Body
+	if strings.HasSuffix(strings.ToLower(rawURL), ".gz") && !resp.Uncompressed {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("seeds: decompressing %s: %w", rawURL, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	return io.ReadAll(reader)
+}
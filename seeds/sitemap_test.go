@@ -0,0 +1,152 @@
+package seeds
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"webcrawler/crawler"
+)
+
+func newTestLoader(t *testing.T) *Loader {
+	t.Helper()
+	store, err := crawler.OpenStore(filepath.Join(t.TempDir(), "visited.db"))
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return NewLoader("test-agent", store)
+}
+
+func urls(seeds []SeedURL) []string {
+	out := make([]string, len(seeds))
+	for i, s := range seeds {
+		out[i] = s.URL
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestLoaderLoadURLSet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/a</loc><lastmod>2024-01-01</lastmod></url>
+  <url><loc>https://example.com/b</loc></url>
+</urlset>`))
+	}))
+	defer srv.Close()
+
+	l := newTestLoader(t)
+	seeds, err := l.Load(srv.URL)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	got := urls(seeds)
+	want := []string{"https://example.com/a", "https://example.com/b"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Load urls = %v, want %v", got, want)
+	}
+}
+
+func TestLoaderLoadSitemapIndex(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>` + "http://" + r.Host + `/child.xml</loc></sitemap>
+</sitemapindex>`))
+	})
+	mux.HandleFunc("/child.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/child-page</loc></url>
+</urlset>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	l := newTestLoader(t)
+	seeds, err := l.Load(srv.URL + "/sitemap.xml")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	got := urls(seeds)
+	if len(got) != 1 || got[0] != "https://example.com/child-page" {
+		t.Errorf("Load urls = %v, want [https://example.com/child-page]", got)
+	}
+}
+
+func TestLoaderLoadGzipped(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/gz</loc></url>
+</urlset>`))
+	gz.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	l := newTestLoader(t)
+	seeds, err := l.Load(srv.URL + "/sitemap.xml.gz")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	got := urls(seeds)
+	if len(got) != 1 || got[0] != "https://example.com/gz" {
+		t.Errorf("Load urls = %v, want [https://example.com/gz]", got)
+	}
+}
+
+func TestLoaderSkipsUnchangedOnlyAfterMarkFetched(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/a</loc><lastmod>2024-01-01</lastmod></url>
+</urlset>`))
+	}))
+	defer srv.Close()
+
+	l := newTestLoader(t)
+
+	seeds, err := l.Load(srv.URL)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(seeds) != 1 {
+		t.Fatalf("first load: got %d seeds, want 1 (nothing recorded yet)", len(seeds))
+	}
+
+	// Without a successful crawl calling MarkFetched, a second load must
+	// still return the URL rather than silently dropping it.
+	seeds, err = l.Load(srv.URL)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(seeds) != 1 {
+		t.Fatalf("second load before MarkFetched: got %d seeds, want 1", len(seeds))
+	}
+
+	if err := l.MarkFetched(seeds[0].URL, seeds[0].LastMod); err != nil {
+		t.Fatalf("MarkFetched: %v", err)
+	}
+
+	seeds, err = l.Load(srv.URL)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(seeds) != 0 {
+		t.Fatalf("third load after MarkFetched: got %d seeds, want 0 (unchanged)", len(seeds))
+	}
+}